@@ -0,0 +1,64 @@
+package OTP
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"time"
+)
+
+// Option configures a OneTimePassword built with NewWithOptions.
+type Option func(*OneTimePassword)
+
+// WithHash overrides the HMAC hash algorithm, e.g. sha256.New.
+func WithHash(h func() hash.Hash) Option {
+	return func(otp *OneTimePassword) { otp.Hash = h }
+}
+
+// WithTimeStep overrides the TOTP step length.
+func WithTimeStep(step time.Duration) Option {
+	return func(otp *OneTimePassword) { otp.TimeStep = step }
+}
+
+// WithBaseTime overrides the TOTP base time.
+func WithBaseTime(t time.Time) Option {
+	return func(otp *OneTimePassword) { otp.BaseTime = t }
+}
+
+// WithDigits overrides the generated code length.
+func WithDigits(digit int) Option {
+	return func(otp *OneTimePassword) { otp.Digit = digit }
+}
+
+// defaultDigit is the code length NewWithOptions starts from before
+// applying opts; pass WithDigits to override it.
+const defaultDigit = 6
+
+// NewWithOptions returns a new OneTimePassword with the same defaults
+// as New(defaultDigit), then applies each opt in order. The resulting
+// Digit is validated after all options have run, so WithDigits cannot
+// be used to bypass the 6-10 digit bound.
+func NewWithOptions(opts ...Option) (otp OneTimePassword, err error) {
+	otp, err = New(defaultDigit)
+	if err != nil {
+		return
+	}
+	for _, opt := range opts {
+		opt(&otp)
+	}
+	if err = validateDigit(otp.Digit); err != nil {
+		otp = OneTimePassword{}
+		return
+	}
+	return
+}
+
+// NewSHA256 is like New but uses SHA-256 as the HMAC hash algorithm.
+func NewSHA256(digit int) (otp OneTimePassword, err error) {
+	return NewWithOptions(WithDigits(digit), WithHash(sha256.New))
+}
+
+// NewSHA512 is like New but uses SHA-512 as the HMAC hash algorithm.
+func NewSHA512(digit int) (otp OneTimePassword, err error) {
+	return NewWithOptions(WithDigits(digit), WithHash(sha512.New))
+}
@@ -0,0 +1,83 @@
+package OTP
+
+import (
+	"fmt"
+	"time"
+)
+
+// Encoder renders the truncated HOTP/TOTP value snum as a string of the
+// given length. The default is DecimalEncoder; SteamEncoder and
+// BaseNEncoder adapt the same HOTP/TOTP truncation pipeline to
+// alphabets used by deployments like Steam Guard.
+type Encoder interface {
+	Encode(snum uint32, length int) string
+}
+
+// DecimalEncoder renders snum as a zero-padded base-10 string, the
+// classic RFC-4226 representation.
+type DecimalEncoder struct{}
+
+// Encode implements Encoder.
+func (DecimalEncoder) Encode(snum uint32, length int) string {
+	return fmt.Sprintf("%0*d", length, uint64(snum)%uint64(pow10For(length)))
+}
+
+// steamAlphabet is the 26-character alphabet used by Steam Guard,
+// chosen to avoid visually ambiguous characters.
+const steamAlphabet = "23456789BCDFGHJKMNPQRTVWXY"
+
+// steamCodeLength is the fixed length of a real Steam Guard code. Steam
+// clients never vary it, so SteamEncoder ignores the length passed to
+// Encode and always produces a code of this length, even when the
+// OneTimePassword's Digit is set to the 6-10 range required by New and
+// the other constructors.
+const steamCodeLength = 5
+
+// SteamEncoder renders snum as a Steam Guard style code, mapping the
+// 31-bit truncated value through steamAlphabet. The rendered code is
+// always steamCodeLength characters, regardless of the Digit configured
+// on the OneTimePassword.
+type SteamEncoder struct{}
+
+// Encode implements Encoder. length is ignored; see steamCodeLength.
+func (SteamEncoder) Encode(snum uint32, length int) string {
+	return BaseNEncoder{Alphabet: steamAlphabet}.Encode(snum, steamCodeLength)
+}
+
+// BaseNEncoder renders snum using an arbitrary alphabet, one character
+// per digit of the target base, least-significant digit first.
+type BaseNEncoder struct {
+	Alphabet string
+}
+
+// Encode implements Encoder.
+func (e BaseNEncoder) Encode(snum uint32, length int) string {
+	base := uint32(len(e.Alphabet))
+	code := make([]byte, length)
+	for i := range code {
+		code[i] = e.Alphabet[snum%base]
+		snum /= base
+	}
+	return string(code)
+}
+
+// encoder returns otp.Encoder, defaulting to DecimalEncoder when unset.
+func (otp *OneTimePassword) encoder() Encoder {
+	if otp.Encoder == nil {
+		return DecimalEncoder{}
+	}
+	return otp.Encoder
+}
+
+// HOTPString returns the HOTP code for secret and count rendered
+// through otp.Encoder.
+func (otp *OneTimePassword) HOTPString(secret []byte, count uint64) string {
+	hs := otp.hmacSum(secret, count)
+	return otp.encoder().Encode(rawTruncate(hs), otp.Digit)
+}
+
+// TOTPString is the TOTP equivalent of HOTPString, using the current
+// time.
+func (otp *OneTimePassword) TOTPString(secret []byte) string {
+	return otp.HOTPString(secret, otp.steps(time.Now()))
+}
@@ -0,0 +1,82 @@
+package OTP
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestHOTPStringDefaultEncoderMatchesHOTP(t *testing.T) {
+	otp, err := New(6)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	secret := []byte("SOME_SECRET")
+
+	got := otp.HOTPString(secret, 123456)
+	want := fmt.Sprintf("%06d", otp.HOTP(secret, 123456))
+	if got != want {
+		t.Errorf("HOTPString = %q, want %q", got, want)
+	}
+}
+
+func TestTOTPStringDefaultEncoderMatchesTOTP(t *testing.T) {
+	otp, err := New(8)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	secret := []byte("SOME_SECRET")
+
+	got := otp.TOTPString(secret)
+	want := fmt.Sprintf("%08d", otp.TOTP(secret))
+	if got != want {
+		t.Errorf("TOTPString = %q, want %q", got, want)
+	}
+}
+
+func TestHOTPStringSteamEncoder(t *testing.T) {
+	// Digit is set to the constructors' 6-10 minimum; SteamEncoder must
+	// still render a real, 5-character Steam Guard code regardless.
+	otp, err := New(6)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	otp.Encoder = SteamEncoder{}
+	secret := []byte("SOME_SECRET")
+
+	code := otp.HOTPString(secret, 1)
+	if len(code) != steamCodeLength {
+		t.Fatalf("len(code) = %d, want %d", len(code), steamCodeLength)
+	}
+	for _, r := range code {
+		if !strings.ContainsRune(steamAlphabet, r) {
+			t.Fatalf("code %q contains %q, not in the Steam alphabet", code, r)
+		}
+	}
+}
+
+func TestBaseNEncoderRoundTripsDigits(t *testing.T) {
+	enc := BaseNEncoder{Alphabet: "0123456789ABCDEF"}
+	code := enc.Encode(0xBEEF, 4)
+	if code != "FEEB" {
+		t.Errorf("Encode(0xBEEF, 4) = %q, want %q (little-endian hex digits)", code, "FEEB")
+	}
+}
+
+func TestDecimalEncoderZeroPads(t *testing.T) {
+	enc := DecimalEncoder{}
+	got := enc.Encode(42, 6)
+	if got != "000042" {
+		t.Errorf("Encode(42, 6) = %q, want %q", got, "000042")
+	}
+}
+
+func TestDecimalEncoderClampsOutOfRangeLength(t *testing.T) {
+	enc := DecimalEncoder{}
+	// Must not panic even for a length outside the pow10 table.
+	got := enc.Encode(123, 99)
+	if _, err := strconv.Atoi(got); err != nil {
+		t.Errorf("Encode(123, 99) = %q, not numeric: %v", got, err)
+	}
+}
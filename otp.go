@@ -11,33 +11,47 @@ import (
 	"errors"
 	"fmt"
 	"hash"
-	"math"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// pow10 is an integer power-of-ten lookup table indexed by digit count,
+// used by truncate instead of math.Pow to avoid float rounding.
+var pow10 = [...]uint{
+	1, 10, 100, 1000, 10000, 100000, 1000000, 10000000, 100000000,
+	1000000000, 10000000000,
+}
+
 // OneTimePassword stores the configuration values relevant to HOTP/TOTP calculations.
 type OneTimePassword struct {
 	Digit    int              // Length of code generated
 	TimeStep time.Duration    // Length of each time step for TOTP
 	BaseTime time.Time        // The start time for TOTP step calculation
 	Hash     func() hash.Hash // Hash algorithm used with HMAC
+	Encoder  Encoder          // Alphabet used by HOTPString/TOTPString. Nil means DecimalEncoder.
+}
+
+// validateDigit reports whether digit is within the 6-10 range
+// supported by HOTP/TOTP code generation.
+func validateDigit(digit int) error {
+	if digit < 6 {
+		return errors.New("minimum of 6 digits is required for a valid HTOP code")
+	} else if digit > 10 {
+		return errors.New("HTOP code cannot be longer than 10 digits")
+	}
+	return nil
 }
 
 // New returns a new OneTimePassword with the specified HTOP code length,
 // SHA-1 as the HMAC hash algorithm, the Unix epoch as the base time, and
 // 30 seconds as the step length.
 func New(digit int) (otp OneTimePassword, err error) {
-	if digit < 6 {
-		err = errors.New("minimum of 6 digits is required for a valid HTOP code")
-		return
-	} else if digit > 9 {
-		err = errors.New("HTOP code cannot be longer than 9 digits")
+	if err = validateDigit(digit); err != nil {
 		return
 	}
 	const step = 30 * time.Second
-	otp = OneTimePassword{digit, step, time.Unix(0, 0), sha1.New}
+	otp = OneTimePassword{digit, step, time.Unix(0, 0), sha1.New, DecimalEncoder{}}
 	return
 }
 
@@ -54,10 +68,28 @@ func (otp *OneTimePassword) hmacSum(secret []byte, count uint64) []byte {
 }
 
 func (otp *OneTimePassword) truncate(hs []byte) uint {
+	return uint(rawTruncate(hs)) % pow10For(otp.Digit)
+}
+
+// pow10For returns the pow10 table entry for n, clamping n into the
+// table's bounds so a OneTimePassword built or mutated with an
+// out-of-range Digit cannot index out of range here.
+func pow10For(n int) uint {
+	if n < 0 {
+		n = 0
+	} else if n >= len(pow10) {
+		n = len(pow10) - 1
+	}
+	return pow10[n]
+}
+
+// rawTruncate implements the RFC-4226 section 5.3 dynamic truncation,
+// returning the 31-bit value before it is reduced modulo 10^Digit.
+func rawTruncate(hs []byte) uint32 {
 	sbits := dt(hs)
-	snum := uint(sbits[3]) | uint(sbits[2])<<8
-	snum |= uint(sbits[1])<<16 | uint(sbits[0])<<24
-	return snum % uint(math.Pow(10, float64(otp.Digit)))
+	snum := uint32(sbits[3]) | uint32(sbits[2])<<8
+	snum |= uint32(sbits[1])<<16 | uint32(sbits[0])<<24
+	return snum
 }
 
 // TOTP returns a TOTP code calculated with the current time and the given secret.
@@ -0,0 +1,92 @@
+package OTP
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"testing"
+	"time"
+)
+
+// TestRFC6238Vectors locks HOTP/TOTP to the reference values from
+// RFC 6238 Appendix B, for all three hash algorithms and every listed
+// timestamp.
+func TestRFC6238Vectors(t *testing.T) {
+	secretSHA1 := []byte("12345678901234567890")
+	secretSHA256 := []byte("12345678901234567890123456789012")
+	secretSHA512 := []byte("1234567890123456789012345678901234567890123456789012345678901234")
+
+	cases := []struct {
+		alg    string
+		t      int64
+		secret []byte
+		want   uint
+	}{
+		{"SHA1", 59, secretSHA1, 94287082},
+		{"SHA256", 59, secretSHA256, 46119246},
+		{"SHA512", 59, secretSHA512, 90693936},
+
+		{"SHA1", 1111111109, secretSHA1, 7081804},
+		{"SHA256", 1111111109, secretSHA256, 68084774},
+		{"SHA512", 1111111109, secretSHA512, 25091201},
+
+		{"SHA1", 1111111111, secretSHA1, 14050471},
+		{"SHA256", 1111111111, secretSHA256, 67062674},
+		{"SHA512", 1111111111, secretSHA512, 99943326},
+
+		{"SHA1", 1234567890, secretSHA1, 89005924},
+		{"SHA256", 1234567890, secretSHA256, 91819424},
+		{"SHA512", 1234567890, secretSHA512, 93441116},
+
+		{"SHA1", 2000000000, secretSHA1, 69279037},
+		{"SHA256", 2000000000, secretSHA256, 90698825},
+		{"SHA512", 2000000000, secretSHA512, 38618901},
+
+		{"SHA1", 20000000000, secretSHA1, 65353130},
+		{"SHA256", 20000000000, secretSHA256, 77737706},
+		{"SHA512", 20000000000, secretSHA512, 47863826},
+	}
+
+	for _, c := range cases {
+		var hashFn func() hash.Hash
+		switch c.alg {
+		case "SHA1":
+			hashFn = sha1.New
+		case "SHA256":
+			hashFn = sha256.New
+		case "SHA512":
+			hashFn = sha512.New
+		}
+
+		otp, err := NewWithOptions(WithDigits(8), WithHash(hashFn))
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		now := time.Unix(c.t, 0)
+		got := otp.HOTP(c.secret, otp.steps(now))
+		if got != c.want {
+			t.Errorf("%s T=%d: got %d, want %d", c.alg, c.t, got, c.want)
+		}
+	}
+}
+
+// TestNewWithOptionsRejectsOutOfRangeDigits guards against WithDigits
+// bypassing the digit bound that New enforces: NewWithOptions must
+// re-validate Digit after applying opts, not just before.
+func TestNewWithOptionsRejectsOutOfRangeDigits(t *testing.T) {
+	if _, err := NewWithOptions(WithDigits(12)); err == nil {
+		t.Fatal("NewWithOptions(WithDigits(12)) should return an error")
+	}
+	if _, err := NewWithOptions(WithDigits(3)); err == nil {
+		t.Fatal("NewWithOptions(WithDigits(3)) should return an error")
+	}
+
+	otp, err := NewWithOptions(WithDigits(10))
+	if err != nil {
+		t.Fatalf("NewWithOptions(WithDigits(10)): %v", err)
+	}
+	// Must not panic, even at the top of the supported range.
+	otp.HOTP([]byte("SOME_SECRET"), 0)
+}
@@ -0,0 +1,79 @@
+package OTP
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// ProvisioningURI returns an otpauth://totp/... key URI for secret,
+// in the format understood by Google Authenticator, Authy, 1Password
+// and similar authenticator apps.
+func (otp *OneTimePassword) ProvisioningURI(issuer, account string, secret []byte) string {
+	return otp.provisioningURI("totp", issuer, account, secret, -1)
+}
+
+// HOTPProvisioningURI is the HOTP equivalent of ProvisioningURI,
+// returning an otpauth://hotp/... key URI seeded with the given
+// starting counter.
+func (otp *OneTimePassword) HOTPProvisioningURI(issuer, account string, secret []byte, counter uint64) string {
+	return otp.provisioningURI("hotp", issuer, account, secret, int64(counter))
+}
+
+func (otp *OneTimePassword) provisioningURI(kind, issuer, account string, secret []byte, counter int64) string {
+	label := fmt.Sprintf("%s:%s", issuer, account)
+
+	v := url.Values{}
+	v.Set("secret", base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret))
+	v.Set("issuer", issuer)
+	v.Set("algorithm", hashName(otp.Hash))
+	v.Set("digits", strconv.Itoa(otp.Digit))
+	if kind == "hotp" {
+		v.Set("counter", strconv.FormatInt(counter, 10))
+	} else {
+		v.Set("period", strconv.Itoa(int(otp.TimeStep.Seconds())))
+	}
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     kind,
+		Path:     "/" + label,
+		RawQuery: v.Encode(),
+	}
+	return u.String()
+}
+
+// hashName maps otp.Hash to the algorithm name expected in a
+// provisioning URI, defaulting to SHA1 since that is what New configures.
+func hashName(h func() hash.Hash) string {
+	switch reflect.ValueOf(h).Pointer() {
+	case reflect.ValueOf(sha256.New).Pointer():
+		return "SHA256"
+	case reflect.ValueOf(sha512.New).Pointer():
+		return "SHA512"
+	default:
+		return "SHA1"
+	}
+}
+
+// QRCodeRenderer renders a provisioning URI as an image. It exists so
+// this module can offer QR codes without taking a hard dependency on a
+// particular QR encoder; plug in e.g. github.com/skip2/go-qrcode.
+type QRCodeRenderer interface {
+	Render(uri string) ([]byte, error)
+}
+
+// QRCode renders uri (as returned by ProvisioningURI) to an image using
+// renderer, typically a PNG.
+func QRCode(uri string, renderer QRCodeRenderer) ([]byte, error) {
+	if renderer == nil {
+		return nil, errors.New("OTP: no QRCodeRenderer configured")
+	}
+	return renderer.Render(uri)
+}
@@ -0,0 +1,105 @@
+package OTP
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestProvisioningURI(t *testing.T) {
+	otp, err := New(6)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	secret := []byte("SOME_SECRET")
+
+	uri := otp.ProvisioningURI("Example", "alice@example.com", secret)
+	u, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("url.Parse(%q): %v", uri, err)
+	}
+
+	if u.Scheme != "otpauth" || u.Host != "totp" {
+		t.Fatalf("ProvisioningURI = %q, want otpauth://totp/...", uri)
+	}
+	if u.Path != "/Example:alice@example.com" {
+		t.Fatalf("path = %q, want %q", u.Path, "/Example:alice@example.com")
+	}
+
+	q := u.Query()
+	if q.Get("issuer") != "Example" {
+		t.Errorf("issuer = %q, want Example", q.Get("issuer"))
+	}
+	if q.Get("algorithm") != "SHA1" {
+		t.Errorf("algorithm = %q, want SHA1", q.Get("algorithm"))
+	}
+	if q.Get("digits") != "6" {
+		t.Errorf("digits = %q, want 6", q.Get("digits"))
+	}
+	if q.Get("period") != "30" {
+		t.Errorf("period = %q, want 30", q.Get("period"))
+	}
+	if q.Get("counter") != "" {
+		t.Errorf("counter = %q, want empty for a TOTP URI", q.Get("counter"))
+	}
+}
+
+func TestHOTPProvisioningURI(t *testing.T) {
+	otp, err := NewSHA256(8)
+	if err != nil {
+		t.Fatalf("NewSHA256: %v", err)
+	}
+	secret := []byte("SOME_SECRET")
+
+	uri := otp.HOTPProvisioningURI("Example", "alice@example.com", secret, 42)
+	u, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("url.Parse(%q): %v", uri, err)
+	}
+
+	if u.Host != "hotp" {
+		t.Fatalf("host = %q, want hotp", u.Host)
+	}
+	q := u.Query()
+	if q.Get("algorithm") != "SHA256" {
+		t.Errorf("algorithm = %q, want SHA256", q.Get("algorithm"))
+	}
+	if q.Get("counter") != "42" {
+		t.Errorf("counter = %q, want 42", q.Get("counter"))
+	}
+	if q.Get("period") != "" {
+		t.Errorf("period = %q, want empty for a HOTP URI", q.Get("period"))
+	}
+}
+
+type stubRenderer struct {
+	uri string
+	err error
+}
+
+func (s *stubRenderer) Render(uri string) ([]byte, error) {
+	s.uri = uri
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []byte("png-bytes"), nil
+}
+
+func TestQRCode(t *testing.T) {
+	r := &stubRenderer{}
+	png, err := QRCode("otpauth://totp/Example:alice", r)
+	if err != nil {
+		t.Fatalf("QRCode: %v", err)
+	}
+	if string(png) != "png-bytes" {
+		t.Errorf("QRCode result = %q, want png-bytes", png)
+	}
+	if r.uri != "otpauth://totp/Example:alice" {
+		t.Errorf("renderer got uri %q", r.uri)
+	}
+}
+
+func TestQRCodeNilRenderer(t *testing.T) {
+	if _, err := QRCode("otpauth://totp/Example:alice", nil); err == nil {
+		t.Fatal("QRCode with a nil renderer should return an error")
+	}
+}
@@ -0,0 +1,59 @@
+package OTP
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"strings"
+)
+
+// DefaultSecretBytes is the shared secret length generated by
+// GenerateSecret when nBytes is <= 0: 20 bytes (160 bits), the minimum
+// recommended by RFC 4226 section 4 for HMAC-SHA1. Use 32 bytes for
+// SHA-256 and 64 bytes for SHA-512.
+const DefaultSecretBytes = 20
+
+// GenerateSecret draws nBytes of randomness from crypto/rand and
+// returns both the raw key and its RFC-4648 base32 (no padding,
+// uppercase) encoding, suitable for ProvisioningURI or manual entry.
+// nBytes <= 0 defaults to DefaultSecretBytes.
+func GenerateSecret(nBytes int) ([]byte, string, error) {
+	if nBytes <= 0 {
+		nBytes = DefaultSecretBytes
+	}
+
+	key := make([]byte, nBytes)
+	if _, err := rand.Read(key); err != nil {
+		return nil, "", fmt.Errorf("OTP: generating secret: %w", err)
+	}
+
+	return key, base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(key), nil
+}
+
+// MustGenerateSecret is like GenerateSecret but panics on error, for
+// callers that only ever expect crypto/rand to fail in unrecoverable
+// situations (e.g. program startup).
+func MustGenerateSecret(nBytes int) ([]byte, string) {
+	key, encoded, err := GenerateSecret(nBytes)
+	if err != nil {
+		panic(err)
+	}
+	return key, encoded
+}
+
+// ParseSecret decodes a base32 shared secret, tolerating the lowercase,
+// spaced-out and unpadded forms users commonly end up with when
+// copy-pasting a secret by hand.
+func ParseSecret(s string) ([]byte, error) {
+	s = strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	if rem := len(s) % 8; rem != 0 {
+		s += strings.Repeat("=", 8-rem)
+	}
+
+	key, err := base32.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("OTP: parsing secret: %w", err)
+	}
+
+	return key, nil
+}
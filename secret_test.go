@@ -0,0 +1,83 @@
+package OTP
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateSecretDefaultLength(t *testing.T) {
+	key, encoded, err := GenerateSecret(0)
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	if len(key) != DefaultSecretBytes {
+		t.Errorf("len(key) = %d, want %d", len(key), DefaultSecretBytes)
+	}
+
+	decoded, err := ParseSecret(encoded)
+	if err != nil {
+		t.Fatalf("ParseSecret: %v", err)
+	}
+	if string(decoded) != string(key) {
+		t.Error("ParseSecret(encoded) did not round-trip to the same key")
+	}
+}
+
+func TestGenerateSecretCustomLength(t *testing.T) {
+	key, _, err := GenerateSecret(32)
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	if len(key) != 32 {
+		t.Errorf("len(key) = %d, want 32", len(key))
+	}
+}
+
+func TestMustGenerateSecret(t *testing.T) {
+	key, encoded := MustGenerateSecret(20)
+	if len(key) != 20 || encoded == "" {
+		t.Fatalf("MustGenerateSecret returned key len %d, encoded %q", len(key), encoded)
+	}
+}
+
+func TestParseSecretTolerantFormats(t *testing.T) {
+	_, canonical, err := GenerateSecret(20)
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+
+	variants := []string{
+		strings.ToLower(canonical),
+		insertSpaces(canonical),
+		canonical + strings.Repeat("=", (8-len(canonical)%8)%8),
+	}
+
+	for _, v := range variants {
+		key, err := ParseSecret(v)
+		if err != nil {
+			t.Errorf("ParseSecret(%q): %v", v, err)
+			continue
+		}
+		want, _ := ParseSecret(canonical)
+		if string(key) != string(want) {
+			t.Errorf("ParseSecret(%q) did not match the canonical decoding", v)
+		}
+	}
+}
+
+func TestParseSecretInvalid(t *testing.T) {
+	if _, err := ParseSecret("not valid base32!!"); err == nil {
+		t.Fatal("ParseSecret should reject non-base32 input")
+	}
+}
+
+func insertSpaces(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
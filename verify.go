@@ -0,0 +1,166 @@
+package OTP
+
+import (
+	"container/list"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// VerifyOptions controls how Verify checks a submitted TOTP code.
+type VerifyOptions struct {
+	Skew  *int        // Number of steps before/after the current one to accept. Nil means the default of 1; a pointer to 0 requires an exact match.
+	Key   string      // Identifier (e.g. username) used to scope replay protection. Required when Cache is set.
+	Cache ReplayCache // Optional cache used to reject codes that have already been verified once.
+}
+
+// Verify checks code against secret for the current time step, accepting
+// codes from opts.Skew steps (default 1) before or after the current one
+// to absorb clock drift between client and server. It returns the matched step so
+// the caller can enforce monotonically increasing steps, alongside
+// whether the code was valid.
+func (otp *OneTimePassword) Verify(secret []byte, code uint, opts VerifyOptions) (bool, int, error) {
+	skew := 1
+	if opts.Skew != nil {
+		skew = *opts.Skew
+	}
+	if skew < 0 {
+		return false, 0, errors.New("OTP: Skew must not be negative")
+	}
+	if opts.Cache != nil && opts.Key == "" {
+		return false, 0, errors.New("OTP: VerifyOptions.Key is required when Cache is set")
+	}
+
+	now := int64(otp.steps(time.Now()))
+	for d := -skew; d <= skew; d++ {
+		step := now + int64(d)
+		if step < 0 {
+			continue
+		}
+		candidate := otp.HOTP(secret, uint64(step))
+		if !constantTimeEqual(candidate, code, otp.Digit) {
+			continue
+		}
+
+		if opts.Cache != nil && opts.Cache.CheckAndMark(opts.Key, step) {
+			return false, 0, errors.New("OTP: code already used")
+		}
+		return true, int(step), nil
+	}
+
+	return false, 0, nil
+}
+
+// VerifyString is the string-code equivalent of Verify, for callers that
+// read the submitted code from a form field.
+func (otp *OneTimePassword) VerifyString(secret []byte, code string, opts VerifyOptions) (bool, int, error) {
+	n, err := strconv.ParseUint(code, 10, 64)
+	if err != nil {
+		return false, 0, fmt.Errorf("OTP: invalid code %q: %w", code, err)
+	}
+	return otp.Verify(secret, uint(n), opts)
+}
+
+// HOTPVerify checks code against secret starting at counter, scanning
+// forward up to lookAhead additional counters to resynchronize with a
+// client that has moved ahead (RFC 4226 section 7.4). On a match it
+// returns the counter to use for the next HOTP, i.e. one past the
+// counter that matched.
+func (otp *OneTimePassword) HOTPVerify(secret []byte, code uint, counter uint64, lookAhead int) (bool, uint64, error) {
+	if lookAhead < 0 {
+		return false, counter, errors.New("OTP: lookAhead must not be negative")
+	}
+
+	for i := 0; i <= lookAhead; i++ {
+		c := counter + uint64(i)
+		if constantTimeEqual(otp.HOTP(secret, c), code, otp.Digit) {
+			return true, c + 1, nil
+		}
+	}
+
+	return false, counter, nil
+}
+
+// constantTimeEqual compares two OTP codes in constant time over their
+// zero-padded decimal representation, to avoid leaking how many leading
+// digits matched.
+func constantTimeEqual(a, b uint, digits int) bool {
+	as := fmt.Sprintf("%0*d", digits, a)
+	bs := fmt.Sprintf("%0*d", digits, b)
+	return subtle.ConstantTimeCompare([]byte(as), []byte(bs)) == 1
+}
+
+// ReplayCache records which (key, step) pairs have already been
+// verified successfully, so a valid code cannot be replayed within its
+// own validity window.
+type ReplayCache interface {
+	// CheckAndMark atomically reports whether (key, step) was already
+	// recorded, and if not, records it. The check and the record must
+	// happen as one atomic operation: composing a separate "seen" check
+	// and "mark" call would let concurrent requests for the same
+	// (key, step) race each other and all observe "not seen".
+	CheckAndMark(key string, step int64) (alreadySeen bool)
+}
+
+// LRUReplayCache is an in-memory ReplayCache bounded by capacity,
+// evicting the least-recently-used entry once full. It is safe for
+// concurrent use.
+type LRUReplayCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type replayEntry struct {
+	key  string
+	step int64
+}
+
+// NewLRUReplayCache returns an LRUReplayCache holding up to capacity
+// entries. A capacity <= 0 defaults to 1024.
+func NewLRUReplayCache(capacity int) *LRUReplayCache {
+	if capacity <= 0 {
+		capacity = 1024
+	}
+	return &LRUReplayCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func replayKey(key string, step int64) string {
+	return fmt.Sprintf("%s:%d", key, step)
+}
+
+// CheckAndMark implements ReplayCache. The check and the insertion run
+// under the same lock, so concurrent callers for the same (key, step)
+// cannot both observe "not seen".
+func (c *LRUReplayCache) CheckAndMark(key string, step int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := replayKey(key, step)
+	if el, ok := c.entries[k]; ok {
+		c.order.MoveToFront(el)
+		return true
+	}
+
+	el := c.order.PushFront(replayEntry{key, step})
+	c.entries[k] = el
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			e := oldest.Value.(replayEntry)
+			delete(c.entries, replayKey(e.key, e.step))
+		}
+	}
+
+	return false
+}
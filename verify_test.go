@@ -0,0 +1,132 @@
+package OTP
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVerifyAcceptsWithinSkew(t *testing.T) {
+	otp, err := New(6)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	secret := []byte("SOME_SECRET")
+
+	next := otp.steps(time.Now()) + 1
+	code := otp.HOTP(secret, next)
+
+	ok, step, err := otp.Verify(secret, code, VerifyOptions{})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !ok || step != int(next) {
+		t.Fatalf("Verify(default skew) = (%v, %d), want (true, %d)", ok, step, next)
+	}
+}
+
+func TestVerifyZeroSkewRequiresExactStep(t *testing.T) {
+	otp, err := New(6)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	secret := []byte("SOME_SECRET")
+
+	next := otp.steps(time.Now()) + 1
+	code := otp.HOTP(secret, next)
+	zero := 0
+
+	ok, _, err := otp.Verify(secret, code, VerifyOptions{Skew: &zero})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if ok {
+		t.Fatal("Verify with Skew: &0 accepted a code outside the current step")
+	}
+
+	cur := otp.steps(time.Now())
+	code = otp.HOTP(secret, cur)
+	ok, step, err := otp.Verify(secret, code, VerifyOptions{Skew: &zero})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !ok || step != int(cur) {
+		t.Fatalf("Verify(exact step) = (%v, %d), want (true, %d)", ok, step, cur)
+	}
+}
+
+func TestVerifyRequiresKeyWhenCacheSet(t *testing.T) {
+	otp, err := New(6)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	secret := []byte("SOME_SECRET")
+	code := otp.TOTP(secret)
+
+	_, _, err = otp.Verify(secret, code, VerifyOptions{Cache: NewLRUReplayCache(0)})
+	if err == nil {
+		t.Fatal("Verify with a Cache but no Key should return an error")
+	}
+}
+
+func TestVerifyRejectsReplay(t *testing.T) {
+	otp, err := New(6)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	secret := []byte("SOME_SECRET")
+	cache := NewLRUReplayCache(0)
+	opts := VerifyOptions{Key: "alice", Cache: cache}
+	code := otp.TOTP(secret)
+
+	ok, _, err := otp.Verify(secret, code, opts)
+	if err != nil || !ok {
+		t.Fatalf("first Verify = (%v, %v), want (true, nil)", ok, err)
+	}
+
+	ok, _, err = otp.Verify(secret, code, opts)
+	if ok || err == nil {
+		t.Fatalf("replayed Verify = (%v, %v), want (false, error)", ok, err)
+	}
+}
+
+func TestHOTPVerifyResync(t *testing.T) {
+	otp, err := New(6)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	secret := []byte("SOME_SECRET")
+	code := otp.HOTP(secret, 5)
+
+	ok, next, err := otp.HOTPVerify(secret, code, 0, 10)
+	if err != nil {
+		t.Fatalf("HOTPVerify: %v", err)
+	}
+	if !ok || next != 6 {
+		t.Fatalf("HOTPVerify = (%v, %d), want (true, 6)", ok, next)
+	}
+
+	ok, _, err = otp.HOTPVerify(secret, code, 6, 10)
+	if err != nil {
+		t.Fatalf("HOTPVerify: %v", err)
+	}
+	if ok {
+		t.Fatal("HOTPVerify matched a counter outside the lookAhead window")
+	}
+}
+
+func TestLRUReplayCacheEviction(t *testing.T) {
+	c := NewLRUReplayCache(2)
+	c.CheckAndMark("alice", 1)
+	c.CheckAndMark("alice", 2)
+	c.CheckAndMark("alice", 3) // evicts step 1
+
+	// Check the still-present steps first: CheckAndMark on a hit only
+	// refreshes recency, so this doesn't disturb the state under test.
+	// Checking the evicted step would itself insert it, so that comes last.
+	if !c.CheckAndMark("alice", 2) || !c.CheckAndMark("alice", 3) {
+		t.Fatal("steps 2 and 3 should still be cached")
+	}
+	if c.CheckAndMark("alice", 1) {
+		t.Fatal("step 1 should have been evicted")
+	}
+}